@@ -0,0 +1,124 @@
+package session
+
+import (
+	"context"
+	"net/http"
+)
+
+// CookieOptions configures the cookie written by BeginSessionCookie and
+// cleared by EndSessionCookie.
+type CookieOptions struct {
+	// Name is the cookie name, e.g. "dazzling_sid".
+	Name string
+	// Path restricts the cookie to a URL path prefix. DefaultCookieOptions
+	// sets it to "/"; left empty, no Path attribute is written.
+	Path string
+	// Domain restricts the cookie to a host. Left empty, the browser
+	// scopes it to the request's own host.
+	Domain string
+	// MaxAge is the cookie lifetime in seconds. Zero means a
+	// session cookie that expires when the browser closes.
+	MaxAge int
+	// Secure requires the cookie only be sent over HTTPS.
+	Secure bool
+	// SameSite controls the cookie's cross-site behavior.
+	SameSite http.SameSite
+}
+
+// DefaultCookieOptions returns CookieOptions with conservative defaults:
+// scoped to the whole site, Secure, and SameSite=Lax.
+func DefaultCookieOptions(name string) CookieOptions {
+	return CookieOptions{
+		Name:     name,
+		Path:     "/",
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// CookieTransport carries the signed SID in an HttpOnly cookie, as an
+// alternative to HeaderTransport for browser-only frontends that can't
+// set an Authorization header.
+type CookieTransport struct {
+	// SigningKeys is tried in order when validating an extracted SID;
+	// see ValidateID.
+	SigningKeys []string
+	Options     CookieOptions
+}
+
+// Extract reads and validates the SID carried by the named cookie.
+func (t CookieTransport) Extract(r *http.Request) (SID, error) {
+	c, err := r.Cookie(t.Options.Name)
+	if err != nil {
+		return InvalidSessionID, ErrNoSessionID
+	}
+	return ValidateID(c.Value, t.SigningKeys)
+}
+
+// Write sets an HttpOnly cookie carrying `sid`.
+func (t CookieTransport) Write(w http.ResponseWriter, sid SID) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     t.Options.Name,
+		Value:    string(sid),
+		Path:     t.Options.Path,
+		Domain:   t.Options.Domain,
+		MaxAge:   t.Options.MaxAge,
+		Secure:   t.Options.Secure,
+		HttpOnly: true,
+		SameSite: t.Options.SameSite,
+	})
+}
+
+// Clear emits a Max-Age=0 cookie so the browser drops it immediately.
+func (t CookieTransport) Clear(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     t.Options.Name,
+		Value:    "",
+		Path:     t.Options.Path,
+		Domain:   t.Options.Domain,
+		MaxAge:   -1,
+		Secure:   t.Options.Secure,
+		HttpOnly: true,
+		SameSite: t.Options.SameSite,
+	})
+}
+
+// BeginSessionCookie creates a new SessionID, saves the `sessionState` to
+// the store, and sets a cookie on the response carrying the SessionID,
+// as the cookie-based counterpart to BeginSession.
+func BeginSessionCookie(signingKey string, store Store, sessionState State, w http.ResponseWriter, opts CookieOptions) (SID, error) {
+	sid, err := NewSessionID(signingKey)
+	if err != nil {
+		return InvalidSessionID, err
+	}
+
+	if err := store.Save(context.Background(), sid, sessionState); err != nil {
+		return InvalidSessionID, err
+	}
+
+	CookieTransport{Options: opts}.Write(w, sid)
+	return sid, nil
+}
+
+// GetSessionIDFromCookie extracts and validates the SessionID from the
+// named cookie, as the cookie-based counterpart to GetSessionID.
+func GetSessionIDFromCookie(r *http.Request, signingKeys []string, name string) (SID, error) {
+	return CookieTransport{SigningKeys: signingKeys, Options: CookieOptions{Name: name}}.Extract(r)
+}
+
+// EndSessionCookie extracts the SessionID from the named cookie, deletes
+// the associated data in the provided store, and clears the cookie on
+// the response, returning the extracted SessionID.
+func EndSessionCookie(r *http.Request, signingKeys []string, store Store, w http.ResponseWriter, opts CookieOptions) (SID, error) {
+	sid, err := GetSessionIDFromCookie(r, signingKeys, opts.Name)
+	if err != nil {
+		return InvalidSessionID, err
+	}
+
+	if err := store.Delete(r.Context(), sid); err != nil {
+		return InvalidSessionID, err
+	}
+
+	CookieTransport{Options: opts}.Clear(w)
+	return sid, nil
+}
@@ -0,0 +1,246 @@
+package session
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ticketSecretLength is the length, in bytes, of the per-session AES-256
+// secret generated for each ticket.
+const ticketSecretLength = 32
+
+// Ticket is the session-ticket analogue of a SID: a string combining a
+// redis key with the per-session secret needed to decrypt the state
+// stored under that key, in the form "base64(sid).base64(secret)". The
+// secret is generated fresh for every session and is never persisted
+// anywhere, including redis, so a Redis compromise alone cannot read
+// session state — the attacker would also need the ticket itself.
+type Ticket string
+
+// newTicket joins a SID and its per-session secret into a Ticket.
+func newTicket(sid SID, secret []byte) Ticket {
+	return Ticket(base64.URLEncoding.EncodeToString([]byte(sid)) + "." + base64.URLEncoding.EncodeToString(secret))
+}
+
+// decode splits the ticket back into its SID and secret.
+func (t Ticket) decode() (SID, []byte, error) {
+	parts := strings.SplitN(string(t), ".", 2)
+	if len(parts) != 2 {
+		return InvalidSessionID, nil, ErrInvalidID
+	}
+
+	sidBytes, err := base64.URLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return InvalidSessionID, nil, err
+	}
+
+	secret, err := base64.URLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return InvalidSessionID, nil, err
+	}
+
+	return SID(sidBytes), secret, nil
+}
+
+// EncryptedRedisStore stores session state in redis as AES-GCM
+// ciphertext, keyed by a randomly-generated SID. The decryption secret
+// is generated fresh per session and never stored in redis: it's
+// returned to the caller as part of the Ticket, so it travels only in
+// the Authorization header or cookie.
+type EncryptedRedisStore struct {
+	Client          redis.UniversalClient
+	SessionDuration time.Duration
+	// KeyPrefix namespaces the redis keys used to store ciphertext.
+	KeyPrefix string
+}
+
+// NewEncryptedRedisStore constructs an EncryptedRedisStore backed by the
+// given client.
+func NewEncryptedRedisStore(client redis.UniversalClient, sessionDuration time.Duration) *EncryptedRedisStore {
+	return &EncryptedRedisStore{
+		Client:          client,
+		SessionDuration: sessionDuration,
+		KeyPrefix:       defaultKeyPrefix,
+	}
+}
+
+func (es *EncryptedRedisStore) getRedisKey(sid SID) string {
+	return es.KeyPrefix + string(sid)
+}
+
+// Save generates a fresh SID and per-session secret, AES-GCM-encrypts
+// the marshaled `sessionState` with that secret, stores only the
+// ciphertext under the SID, and returns the Ticket combining the two.
+func (es *EncryptedRedisStore) Save(ctx context.Context, sessionState interface{}) (Ticket, error) {
+	idBytes, err := GenerateRandomBytes(idLength)
+	if err != nil {
+		return "", err
+	}
+	sid := SID(base64.URLEncoding.EncodeToString(idBytes))
+
+	secret, err := GenerateRandomBytes(ticketSecretLength)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := json.Marshal(sessionState)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(secret, plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	if err := es.Client.Set(ctx, es.getRedisKey(sid), ciphertext, es.SessionDuration).Err(); err != nil {
+		return "", err
+	}
+
+	return newTicket(sid, secret), nil
+}
+
+// Get splits `ticket` into its SID and secret, fetches the ciphertext
+// stored under the SID, decrypts it with the secret, and populates
+// `sessionState` with the result.
+func (es *EncryptedRedisStore) Get(ctx context.Context, ticket Ticket, sessionState interface{}) error {
+	sid, secret, err := ticket.decode()
+	if err != nil {
+		return err
+	}
+
+	pipeline := es.Client.Pipeline()
+	pipe := pipeline.Get(ctx, es.getRedisKey(sid))
+	pipeline.Expire(ctx, es.getRedisKey(sid), es.SessionDuration)
+
+	if _, err := pipeline.Exec(ctx); err != nil && err != redis.Nil {
+		return err
+	}
+
+	ciphertext, err := pipe.Bytes()
+	if err == redis.Nil {
+		return ErrStateNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := open(secret, ciphertext)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(plaintext, sessionState)
+}
+
+// Delete removes the ciphertext stored under the SID embedded in
+// `ticket`.
+func (es *EncryptedRedisStore) Delete(ctx context.Context, ticket Ticket) error {
+	sid, _, err := ticket.decode()
+	if err != nil {
+		return err
+	}
+	return es.Client.Del(ctx, es.getRedisKey(sid)).Err()
+}
+
+// seal AES-GCM-encrypts `plaintext` with `key`, prepending the nonce to
+// the returned ciphertext.
+func seal(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open reverses seal.
+func open(key, ciphertext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, ErrInvalidID
+	}
+
+	nonce, ct := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ct, nil)
+}
+
+// BeginSessionTicket saves `sessionState` to an EncryptedRedisStore and
+// adds an Authorization header to the response carrying the resulting
+// Ticket, as the encrypted-ticket counterpart to BeginSession.
+func BeginSessionTicket(store *EncryptedRedisStore, sessionState State, w http.ResponseWriter) (Ticket, error) {
+	ticket, err := store.Save(context.Background(), sessionState)
+	if err != nil {
+		return "", err
+	}
+
+	w.Header().Add(headerAuthorization, schemeBearer+string(ticket))
+	return ticket, nil
+}
+
+// GetSessionTicket extracts the Ticket from the request's Authorization
+// header or "auth" query parameter, as the encrypted-ticket counterpart
+// to GetSessionID. The ticket's own AES-GCM tag authenticates it, so no
+// signing key is needed here.
+func GetSessionTicket(r *http.Request) (Ticket, error) {
+	id := r.Header.Get(headerAuthorization)
+	if len(id) == 0 {
+		id = r.URL.Query().Get(paramAuthorization)
+	}
+
+	s := strings.Split(id, " ")
+	switch len(s) {
+	case 0, 1:
+		return "", ErrNoSessionID
+	case 2:
+		if scheme := s[0]; scheme != "Bearer" {
+			return "", ErrInvalidScheme
+		}
+		return Ticket(s[len(s)-1]), nil
+	default:
+		return "", ErrInvalidScheme
+	}
+}
+
+// EndSessionTicket extracts the Ticket from the request and deletes the
+// associated ciphertext from the store, returning the extracted Ticket.
+func EndSessionTicket(r *http.Request, store *EncryptedRedisStore) (Ticket, error) {
+	ticket, err := GetSessionTicket(r)
+	if err != nil {
+		return "", err
+	}
+
+	if err := store.Delete(r.Context(), ticket); err != nil {
+		return "", err
+	}
+
+	return ticket, nil
+}
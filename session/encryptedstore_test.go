@@ -0,0 +1,124 @@
+package session_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bryoco/dazzling/session"
+)
+
+// EncryptedRedisStore doesn't implement session.Store (Save returns a
+// Ticket instead of taking a SID, and Get/Delete take a Ticket rather
+// than a SID), so it can't run through storetest.Run. This test covers
+// the same scenarios against its Ticket-shaped API instead.
+func TestEncryptedRedisStoreConformance(t *testing.T) {
+	// redis expiry has whole-second granularity, so this needs to be
+	// coarser than the millisecond expiry MemStoreConformance uses.
+	const dur = 1 * time.Second
+	ctx := context.Background()
+
+	type testState struct {
+		Name string `json:"name"`
+	}
+
+	newStore := func(t *testing.T) *session.EncryptedRedisStore {
+		return session.NewEncryptedRedisStore(newMiniredisClient(t), dur)
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		store := newStore(t)
+		want := testState{Name: "gopher"}
+
+		ticket, err := store.Save(ctx, want)
+		if err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+
+		var got testState
+		if err := store.Get(ctx, ticket, &got); err != nil {
+			t.Fatalf("Get() returned error: %v", err)
+		}
+		if got != want {
+			t.Fatalf("Get() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("MissingTicketReturnsErrStateNotFound", func(t *testing.T) {
+		store := newStore(t)
+
+		// a well-formed ticket whose SID half was never passed to
+		// Save, so the redis key it names was never written
+		ticket, err := store.Save(ctx, testState{Name: "gopher"})
+		if err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+
+		unwrittenSID, err := session.GenerateRandomBytes(32)
+		if err != nil {
+			t.Fatalf("GenerateRandomBytes: %v", err)
+		}
+		parts := strings.SplitN(string(ticket), ".", 2)
+		missing := session.Ticket(base64.URLEncoding.EncodeToString(unwrittenSID) + "." + parts[1])
+
+		var got testState
+		err = store.Get(ctx, missing, &got)
+		if !errors.Is(err, session.ErrStateNotFound) {
+			t.Fatalf("Get() of missing ticket returned %v, want ErrStateNotFound", err)
+		}
+	})
+
+	t.Run("DeleteThenGet", func(t *testing.T) {
+		store := newStore(t)
+
+		ticket, err := store.Save(ctx, testState{Name: "gopher"})
+		if err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+		if err := store.Delete(ctx, ticket); err != nil {
+			t.Fatalf("Delete() returned error: %v", err)
+		}
+
+		var got testState
+		err = store.Get(ctx, ticket, &got)
+		if !errors.Is(err, session.ErrStateNotFound) {
+			t.Fatalf("Get() after Delete() returned %v, want ErrStateNotFound", err)
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		store := newStore(t)
+
+		ticket, err := store.Save(ctx, testState{Name: "gopher"})
+		if err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+
+		time.Sleep(dur + dur/2)
+
+		var got testState
+		err = store.Get(ctx, ticket, &got)
+		if !errors.Is(err, session.ErrStateNotFound) {
+			t.Fatalf("Get() after expiry returned %v, want ErrStateNotFound", err)
+		}
+	})
+
+	t.Run("TamperedSecretFailsToDecrypt", func(t *testing.T) {
+		store := newStore(t)
+
+		ticket, err := store.Save(ctx, testState{Name: "gopher"})
+		if err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+
+		forged := ticket[:len(ticket)-1] + "x"
+
+		var got testState
+		if err := store.Get(ctx, forged, &got); err == nil {
+			t.Fatal("Get() with a tampered ticket secret succeeded, want error")
+		}
+	})
+}
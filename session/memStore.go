@@ -0,0 +1,117 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// memSweepInterval is how often NewMemStore's background goroutine scans
+// for expired entries.
+const memSweepInterval = time.Minute
+
+type memEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// MemStore is an in-memory Store, for tests and single-node deployments
+// that don't need a shared redis instance. Session state is JSON-encoded
+// internally so its expiry and round-trip semantics match RedisStore.
+type MemStore struct {
+	mu              sync.RWMutex
+	entries         map[SID]memEntry
+	sessionDuration time.Duration
+	done            chan struct{}
+}
+
+// NewMemStore constructs a MemStore whose entries expire `dur` after
+// they were last read or written, and starts a background goroutine
+// that sweeps expired entries every memSweepInterval. Call Close to stop
+// the goroutine once the store is no longer needed.
+func NewMemStore(dur time.Duration) *MemStore {
+	ms := &MemStore{
+		entries:         make(map[SID]memEntry),
+		sessionDuration: dur,
+		done:            make(chan struct{}),
+	}
+	go ms.sweep()
+	return ms
+}
+
+func (ms *MemStore) sweep() {
+	ticker := time.NewTicker(memSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ms.evictExpired()
+		case <-ms.done:
+			return
+		}
+	}
+}
+
+func (ms *MemStore) evictExpired() {
+	now := time.Now()
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	for sid, e := range ms.entries {
+		if now.After(e.expiresAt) {
+			delete(ms.entries, sid)
+		}
+	}
+}
+
+// Close stops the background sweep goroutine.
+func (ms *MemStore) Close() error {
+	close(ms.done)
+	return nil
+}
+
+// Save saves the provided `sessionState` and associated SID to the store.
+func (ms *MemStore) Save(ctx context.Context, sid SID, sessionState interface{}) error {
+	data, err := json.Marshal(sessionState)
+	if err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	ms.entries[sid] = memEntry{
+		data:      data,
+		expiresAt: time.Now().Add(ms.sessionDuration),
+	}
+	ms.mu.Unlock()
+	return nil
+}
+
+// Get populates `sessionState` with the data previously saved for the
+// given SID, and resets its expiry so it doesn't get evicted until
+// SessionDuration has elapsed since this call.
+func (ms *MemStore) Get(ctx context.Context, sid SID, sessionState interface{}) error {
+	now := time.Now()
+
+	ms.mu.Lock()
+	e, ok := ms.entries[sid]
+	if !ok || now.After(e.expiresAt) {
+		delete(ms.entries, sid)
+		ms.mu.Unlock()
+		return ErrStateNotFound
+	}
+	e.expiresAt = now.Add(ms.sessionDuration)
+	ms.entries[sid] = e
+	ms.mu.Unlock()
+
+	return json.Unmarshal(e.data, sessionState)
+}
+
+// Delete deletes all state data associated with the SID from the store.
+func (ms *MemStore) Delete(ctx context.Context, sid SID) error {
+	ms.mu.Lock()
+	delete(ms.entries, sid)
+	ms.mu.Unlock()
+	return nil
+}
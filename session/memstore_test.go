@@ -0,0 +1,18 @@
+package session_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bryoco/dazzling/session"
+	"github.com/bryoco/dazzling/session/storetest"
+)
+
+func TestMemStoreConformance(t *testing.T) {
+	const dur = 50 * time.Millisecond
+
+	store := session.NewMemStore(dur)
+	defer store.Close()
+
+	storetest.Run(t, store, dur)
+}
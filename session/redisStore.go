@@ -1,90 +1,159 @@
 package session
 
 import (
-	"encoding/json"
-	"github.com/go-redis/redis"
+	"context"
+	"github.com/go-redis/redis/v8"
 	"time"
 )
 
 type RedisStore struct {
-	// Redis client used to talk to redis server.
-	Client *redis.Client
+	// Redis client used to talk to redis server. A redis.UniversalClient so
+	// that RedisStore can be backed by a single node, a Sentinel-managed
+	// failover group, or a Cluster deployment interchangeably.
+	Client redis.UniversalClient
 	// Used for key expiry time on redis.
 	SessionDuration time.Duration
+	// Serializer marshals/unmarshals session state to/from the bytes
+	// stored in redis. Defaults to JSONSerializer.
+	Serializer Serializer
+	// KeyPrefix is prepended to every SID to form the redis key, so that
+	// multiple applications can share a redis instance without their
+	// session keys colliding. Defaults to "sid:".
+	KeyPrefix string
 }
 
-// NewRedisClient takes an address and returns a the pointer of new client.
-func NewRedisClient(addr string) *redis.Client {
+// defaultKeyPrefix is the KeyPrefix a RedisStore gets unless overridden
+// with WithKeyPrefix.
+const defaultKeyPrefix = "sid:"
+
+// WithKeyPrefix overrides the default "sid:" prefix used to namespace
+// session keys in redis.
+func WithKeyPrefix(prefix string) Option {
+	return func(rs *RedisStore) {
+		rs.KeyPrefix = prefix
+	}
+}
+
+// Option configures a RedisStore at construction time.
+type Option func(*RedisStore)
+
+// WithSerializer overrides the Serializer used to encode session state,
+// e.g. session.WithSerializer(session.GobSerializer{}).
+func WithSerializer(s Serializer) Option {
+	return func(rs *RedisStore) {
+		rs.Serializer = s
+	}
+}
+
+// NewRedisClient takes an address, password, and DB number and returns
+// the pointer of a new client.
+func NewRedisClient(addr string, password string, db int) *redis.Client {
 	return redis.NewClient(&redis.Options{
 		Addr:     addr,
-		Password: "",
-		DB:       0,
+		Password: password,
+		DB:       db,
 	})
 }
 
-// NewRedisStore constructs a new RedisStore
-func NewRedisStore(client *redis.Client, sessionDuration time.Duration) *RedisStore {
+// NewRedisStore constructs a new RedisStore backed by the given client. The
+// client may be a single-node client, a Sentinel failover client, or a
+// Cluster client, since all of them satisfy redis.UniversalClient. Session
+// state is JSON-encoded unless a different Serializer is supplied via
+// WithSerializer.
+func NewRedisStore(client redis.UniversalClient, sessionDuration time.Duration, opts ...Option) *RedisStore {
 	// initialize and return a new RedisStore struct
-	return &RedisStore{
+	rs := &RedisStore{
 		Client:          client,
 		SessionDuration: sessionDuration,
+		Serializer:      JSONSerializer{},
+		KeyPrefix:       defaultKeyPrefix,
+	}
+	for _, opt := range opts {
+		opt(rs)
 	}
+	return rs
+}
+
+// NewRedisSentinelStore constructs a RedisStore backed by a Sentinel-managed
+// failover group, so that sessions keep working across a master election.
+func NewRedisSentinelStore(masterName string, sentinelAddrs []string, password string, db int, dur time.Duration, opts ...Option) *RedisStore {
+	client := redis.NewFailoverClient(&redis.FailoverOptions{
+		MasterName:    masterName,
+		SentinelAddrs: sentinelAddrs,
+		Password:      password,
+		DB:            db,
+	})
+	return NewRedisStore(client, dur, opts...)
+}
+
+// NewRedisClusterStore constructs a RedisStore backed by a Redis Cluster
+// deployment spread across the given node addresses.
+func NewRedisClusterStore(addrs []string, password string, dur time.Duration, opts ...Option) *RedisStore {
+	client := redis.NewClusterClient(&redis.ClusterOptions{
+		Addrs:    addrs,
+		Password: password,
+	})
+	return NewRedisStore(client, dur, opts...)
 }
 
 // Save saves the provided `sessionState` and associated SID to the store.
 // The `sessionState` parameter is typically a pointer to a struct containing
 // all the data you want to associated with the given SID.
-func (rs *RedisStore) Save(sid SID, sessionState interface{}) error {
-	// marshal the `sessionState` to JSON and save it in the redis database,
-	// using `sid.getRedisKey()` for the key.
+func (rs *RedisStore) Save(ctx context.Context, sid SID, sessionState interface{}) error {
+	// marshal the `sessionState` with rs.Serializer and save it in the
+	// redis database, using `rs.getRedisKey(sid)` for the key.
 	// return any errors that occur along the way.
-	j, err := json.Marshal(sessionState)
+	j, err := rs.Serializer.Marshal(sessionState)
 	if err != nil {
 		return err
 	}
 
-	rs.Client.Set(sid.getRedisKey(), j, rs.SessionDuration)
-	return nil
+	return rs.Client.Set(ctx, rs.getRedisKey(sid), j, rs.SessionDuration).Err()
 }
 
 // DeleteUser deletes all state data associated with the SID from the store.
-func (rs *RedisStore) Delete(sid SID) error {
+func (rs *RedisStore) Delete(ctx context.Context, sid SID) error {
 	// delete the data stored in redis for the provided SID
-	rs.Client.Del(sid.getRedisKey())
-	return nil
+	return rs.Client.Del(ctx, rs.getRedisKey(sid)).Err()
+}
+
+// Ping checks connectivity to redis, so callers can validate the
+// connection at startup before accepting traffic.
+func (rs *RedisStore) Ping(ctx context.Context) error {
+	return rs.Client.Ping(ctx).Err()
 }
 
 // Get populates `sessionState` with the data previously saved
 // for the given SID
-func (rs *RedisStore) Get(sid SID, sessionState interface{}) error {
+func (rs *RedisStore) Get(ctx context.Context, sid SID, sessionState interface{}) error {
 	// get the previously-saved session state data from redis,
 	// unmarshal it back into the `sessionState` parameter
 	// and reset the expiry time, so that it doesn't get deleted until
-	// the SessionDuration has elapsed.
+	// the SessionDuration has elapsed. Pipelined so both calls share one
+	// round trip to the server.
 	pipeline := rs.Client.Pipeline()
-	pipe := pipeline.Get(sid.getRedisKey())
-	pipeline.Expire(sid.getRedisKey(), rs.SessionDuration)
+	pipe := pipeline.Get(ctx, rs.getRedisKey(sid))
+	pipeline.Expire(ctx, rs.getRedisKey(sid), rs.SessionDuration)
 
-	if _, err := pipeline.Exec(); err != nil {
+	if _, err := pipeline.Exec(ctx); err != nil && err != redis.Nil {
 		return err
 	}
 
-	if s, err := pipe.Result(); err != nil {
+	s, err := pipe.Result()
+	if err == redis.Nil {
+		return ErrStateNotFound
+	}
+	if err != nil {
 		return err
-	} else {
-		if err = json.Unmarshal([]byte(s), sessionState); err != nil {
-			// cannot unmarshal
-			return err
-		} else {
-			return nil
-		}
 	}
+
+	return rs.Serializer.Unmarshal([]byte(s), sessionState)
 }
 
-// getRedisKey() returns the redis key to use for the SID
-func (sid SID) getRedisKey() string {
-	// convert the SID to a string and add the prefix "sid:" to keep
-	// SID keys separate from other keys that might end up in this
-	// redis instance
-	return string(sid)
-}
\ No newline at end of file
+// getRedisKey returns the redis key to use for the SID, namespaced with
+// the store's KeyPrefix to keep SID keys separate from other keys that
+// might end up in this redis instance (and from other apps' session keys
+// in a shared instance).
+func (rs *RedisStore) getRedisKey(sid SID) string {
+	return rs.KeyPrefix + string(sid)
+}
@@ -0,0 +1,57 @@
+package session_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/bryoco/dazzling/session"
+	"github.com/bryoco/dazzling/session/storetest"
+)
+
+// newMiniredisClient starts an in-process fake redis server for the
+// duration of the test and returns a client connected to it. miniredis
+// doesn't expire keys as real time passes, only as its own internal
+// clock is advanced, so a background goroutine ticks that clock forward
+// in step with the wall clock for as long as the test runs.
+func newMiniredisClient(t *testing.T) redis.UniversalClient {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis.Run: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	const tick = 10 * time.Millisecond
+	ticker := time.NewTicker(tick)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				mr.FastForward(tick)
+			case <-done:
+				return
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		ticker.Stop()
+		close(done)
+	})
+
+	return redis.NewClient(&redis.Options{Addr: mr.Addr()})
+}
+
+func TestRedisStoreConformance(t *testing.T) {
+	// redis expiry has whole-second granularity, so this needs to be
+	// coarser than the millisecond expiry MemStoreConformance uses.
+	const dur = 1 * time.Second
+
+	store := session.NewRedisStore(newMiniredisClient(t), dur)
+
+	storetest.Run(t, store, dur)
+}
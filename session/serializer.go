@@ -0,0 +1,52 @@
+package session
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Serializer converts session state to and from the byte representation
+// stored in a Store. Implementations must be safe for concurrent use.
+type Serializer interface {
+	// Marshal encodes `v` to its stored byte representation.
+	Marshal(v interface{}) ([]byte, error)
+	// Unmarshal decodes previously-marshaled bytes back into `v`.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONSerializer marshals session state with encoding/json. It is the
+// default Serializer used by NewRedisStore.
+type JSONSerializer struct{}
+
+// Marshal encodes `v` as JSON.
+func (JSONSerializer) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes JSON-encoded `data` into `v`.
+func (JSONSerializer) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// GobSerializer marshals session state with encoding/gob. Unlike
+// JSONSerializer it can round-trip types like time.Time embedded in
+// interface{} fields, map[interface{}]interface{}, and any type that only
+// satisfies gob's GobEncoder/GobDecoder. Concrete types held in
+// interface{} values must be registered with gob.Register first, or
+// Marshal returns an error.
+type GobSerializer struct{}
+
+// Marshal encodes `v` using gob.
+func (GobSerializer) Marshal(v interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes gob-encoded `data` into `v`.
+func (GobSerializer) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"net/http"
 	"strings"
 )
@@ -9,6 +10,11 @@ const headerAuthorization = "Authorization"
 const paramAuthorization = "auth"
 const schemeBearer = "Bearer "
 
+// State is the application-defined session payload passed to
+// BeginSession/BeginSessionCookie and populated by GetState. It's an
+// alias for interface{} so callers can pass any serializable struct.
+type State = interface{}
+
 // BeginSession creates a new SessionID, saves the `sessionState` to the store, adds an
 // Authorization header to the response with the SessionID, and returns the new SessionID
 func BeginSession(signingKey string, store Store, sessionState State, w http.ResponseWriter) (SID, error) {
@@ -24,7 +30,7 @@ func BeginSession(signingKey string, store Store, sessionState State, w http.Res
 		return InvalidSessionID, err
 	}
 
-	if err := store.Save(sid, sessionState); err != nil {
+	if err := store.Save(context.Background(), sid, sessionState); err != nil {
 		return InvalidSessionID, err
 	}
 
@@ -32,8 +38,11 @@ func BeginSession(signingKey string, store Store, sessionState State, w http.Res
 	return sid, nil
 }
 
-// GetSessionID extracts and validates the SessionID from the request headers
-func GetSessionID(r *http.Request, signingKey string) (SID, error) {
+// GetSessionID extracts and validates the SessionID from the request
+// headers. `signingKeys` is tried in order, current key first followed
+// by any still-honored previous keys, so that a signing key can be
+// rotated without invalidating sessions signed under the old one.
+func GetSessionID(r *http.Request, signingKeys []string) (SID, error) {
 
 	// get the value of the Authorization header,
 	id := r.Header.Get(headerAuthorization)
@@ -56,7 +65,7 @@ func GetSessionID(r *http.Request, signingKey string) (SID, error) {
 
 		// If it's valid, return the SessionID. If not return the validation error.
 		id = s[len(s)-1]
-		sid, err := ValidateID(id, signingKey)
+		sid, err := ValidateID(id, signingKeys)
 		if err != nil {
 			return InvalidSessionID, err
 		} else {
@@ -71,15 +80,15 @@ func GetSessionID(r *http.Request, signingKey string) (SID, error) {
 // GetState extracts the SessionID from the request,
 // gets the associated state from the provided store into
 // the `sessionState` parameter, and returns the SessionID
-func GetState(r *http.Request, signingKey string, store RedisStore, sessionState interface{}) (SID, error) {
+func GetState(r *http.Request, signingKeys []string, store Store, sessionState interface{}) (SID, error) {
 	// get the SessionID from the request, and get the data
 	// associated with that SessionID from the store.
-	sid, err := GetSessionID(r, signingKey)
+	sid, err := GetSessionID(r, signingKeys)
 	if err != nil {
 		return InvalidSessionID, err
 	}
 
-	if err := store.Get(sid, sessionState); err != nil {
+	if err := store.Get(r.Context(), sid, sessionState); err != nil {
 		return InvalidSessionID, err
 	}
 
@@ -89,16 +98,16 @@ func GetState(r *http.Request, signingKey string, store RedisStore, sessionState
 // EndSession extracts the SessionID from the request,
 // and deletes the associated data in the provided store, returning
 // the extracted SessionID.
-func EndSession(r *http.Request, signingKey string, store Store) (SID, error) {
+func EndSession(r *http.Request, signingKeys []string, store Store) (SID, error) {
 	// get the SessionID from the request, and delete the
 	// data associated with it in the store.
 
-	sid, err := GetSessionID(r, signingKey)
+	sid, err := GetSessionID(r, signingKeys)
 	if err != nil {
 		return InvalidSessionID, err
 	}
 
-	if err := store.Delete(sid); err != nil {
+	if err := store.Delete(r.Context(), sid); err != nil {
 		return InvalidSessionID, err
 	}
 
@@ -1,7 +1,6 @@
 package session
 
 import (
-	"bytes"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
@@ -9,10 +8,6 @@ import (
 	"errors"
 )
 
-// signedLength is the full length of the signed session ID
-// (ID portion plus signature)
-// const signedLength = idLength + sha256.Size
-
 // SID represents a valid, digitally-signed session ID.
 // This is a base64 URL encoded string created from a byte slice
 // where the first `idLength` bytes are cryptographically random
@@ -30,11 +25,6 @@ const (
 	idLength = 32
 )
 
-var (
-	// SID for current session
-	generatedSID = ""
-)
-
 type SID string
 
 // NewSessionID creates and returns a new digitally-signed session ID,
@@ -60,10 +50,9 @@ func NewSessionID(signingKey string) (SID, error) {
 		return InvalidSessionID, err
 	}
 
-	combined := append(randByte, GenerateRandomHMAC(signingKey)...)
+	combined := append(randByte, GenerateRandomHMAC(signingKey, randByte)...)
 	sid := base64.URLEncoding.EncodeToString(combined)
 
-	generatedSID = sid
 	return SID(sid), nil
 }
 
@@ -82,11 +71,12 @@ func GenerateRandomBytes(n int) ([]byte, error) {
 	}
 }
 
-func GenerateRandomHMAC(signingKey string) []byte {
-	buf := new(bytes.Buffer)
-	b := buf.Bytes()
+// GenerateRandomHMAC computes the HMAC-SHA256 of `id` using `signingKey`
+// as the HMAC key. `id` is the random ID portion of a session ID, not the
+// full signed value.
+func GenerateRandomHMAC(signingKey string, id []byte) []byte {
 	h := hmac.New(sha256.New, []byte(signingKey))
-	h.Write(b)
+	h.Write(id)
 
 	return h.Sum(nil)
 }
@@ -99,38 +89,39 @@ func DecodeSessionID(sid string) ([]byte, error) {
 	return d, nil
 }
 
-func IdHasMutated(id string) bool {
-	return generatedSID == id
-}
-
-// ValidateID validates the string in the `id` parameter
-// using the `signingKey` as the HMAC signing key
-// and returns an error if invalid, or a SID if valid
-func ValidateID(id string, signingKey string) (SID, error) {
+// ValidateID validates the string in the `id` parameter, trying each key
+// in `signingKeys` in turn as the HMAC signing key, and returns an error
+// if invalid, or a SID if valid. Accepting more than one key lets a
+// signing key be rotated without invalidating sessions that were signed
+// with the previous one: pass the current key first, followed by any
+// still-honored previous keys.
+func ValidateID(id string, signingKeys []string) (SID, error) {
 
-	// validate the `id` parameter using the provided `signingKey`.
+	// validate the `id` parameter using the provided `signingKeys`.
 	// base64 decode the `id` parameter, HMAC hash the
 	// ID portion of the byte slice, and compare that to the
 	// HMAC hash stored in the remaining bytes. If they match,
 	// return the entire `id` parameter as a SID type.
 	// If not, return InvalidSessionID and ErrInvalidID.
 
-	//if !IdHasMutated(id) {
-	//	return InvalidSessionID, errors.New("id has mutated")
-	//}
-
-	decodedID, err := DecodeSessionID(id)
+	decoded, err := DecodeSessionID(id)
 	if err != nil {
 		return InvalidSessionID, err
 	}
 
-	// ID portion of the byte slice
-	decodedID = decodedID[idLength:]
+	if len(decoded) <= idLength {
+		return InvalidSessionID, ErrInvalidID
+	}
 
-	match := hmac.Equal(decodedID, GenerateRandomHMAC(signingKey))
-	if match {
-		return SID(id), nil
-	} else {
-		return InvalidSessionID, errors.New("hmac not equal")
+	// ID portion and signature portion of the byte slice
+	idPortion := decoded[:idLength]
+	signature := decoded[idLength:]
+
+	for _, signingKey := range signingKeys {
+		if hmac.Equal(signature, GenerateRandomHMAC(signingKey, idPortion)) {
+			return SID(id), nil
+		}
 	}
+
+	return InvalidSessionID, ErrInvalidID
 }
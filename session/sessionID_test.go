@@ -0,0 +1,71 @@
+package session_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/bryoco/dazzling/session"
+)
+
+func TestValidateIDRejectsTamperedID(t *testing.T) {
+	const signingKey = "current-key"
+
+	sid, err := session.NewSessionID(signingKey)
+	if err != nil {
+		t.Fatalf("NewSessionID: %v", err)
+	}
+
+	decoded, err := session.DecodeSessionID(string(sid))
+	if err != nil {
+		t.Fatalf("DecodeSessionID: %v", err)
+	}
+
+	// flip the bits of the first byte of the random ID portion, leaving
+	// the signature untouched
+	decoded[0] ^= 0xff
+	tampered := base64.URLEncoding.EncodeToString(decoded)
+
+	if _, err := session.ValidateID(tampered, []string{signingKey}); !errors.Is(err, session.ErrInvalidID) {
+		t.Fatalf("ValidateID(tampered) = %v, want ErrInvalidID", err)
+	}
+}
+
+func TestValidateIDRejectsForgedID(t *testing.T) {
+	// an id signed with a key the verifier doesn't know about should
+	// never validate, no matter how it's shaped
+	forged, err := session.NewSessionID("attacker-key")
+	if err != nil {
+		t.Fatalf("NewSessionID: %v", err)
+	}
+
+	if _, err := session.ValidateID(string(forged), []string{"current-key"}); !errors.Is(err, session.ErrInvalidID) {
+		t.Fatalf("ValidateID(forged) = %v, want ErrInvalidID", err)
+	}
+}
+
+func TestValidateIDHonorsRotatedKey(t *testing.T) {
+	const previousKey = "previous-key"
+	const currentKey = "current-key"
+
+	sid, err := session.NewSessionID(previousKey)
+	if err != nil {
+		t.Fatalf("NewSessionID: %v", err)
+	}
+
+	// the current key alone must not validate a session signed under
+	// the previous key
+	if _, err := session.ValidateID(string(sid), []string{currentKey}); !errors.Is(err, session.ErrInvalidID) {
+		t.Fatalf("ValidateID with only current key = %v, want ErrInvalidID", err)
+	}
+
+	// but rotation keeps it valid as long as the previous key is still
+	// honored, current key first
+	got, err := session.ValidateID(string(sid), []string{currentKey, previousKey})
+	if err != nil {
+		t.Fatalf("ValidateID with rotated keys: %v", err)
+	}
+	if got != sid {
+		t.Fatalf("ValidateID returned %q, want %q", got, sid)
+	}
+}
@@ -0,0 +1,80 @@
+// Package storetest provides a conformance test suite that can be run
+// against any session.Store implementation, so new backends can be
+// validated the same way MemStore and RedisStore are.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bryoco/dazzling/session"
+)
+
+type testState struct {
+	Name string `json:"name"`
+}
+
+// Run exercises round-trip save/get, delete-then-get, a missing-key get,
+// and expiry against `store`, whose entries expire after `expiry`.
+func Run(t *testing.T, store session.Store, expiry time.Duration) {
+	t.Helper()
+	ctx := context.Background()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		sid := session.SID("storetest-roundtrip")
+		want := testState{Name: "gopher"}
+
+		if err := store.Save(ctx, sid, want); err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+
+		var got testState
+		if err := store.Get(ctx, sid, &got); err != nil {
+			t.Fatalf("Get() returned error: %v", err)
+		}
+		if got != want {
+			t.Fatalf("Get() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("MissingKeyReturnsErrStateNotFound", func(t *testing.T) {
+		var got testState
+		err := store.Get(ctx, session.SID("storetest-missing"), &got)
+		if !errors.Is(err, session.ErrStateNotFound) {
+			t.Fatalf("Get() of missing key returned %v, want ErrStateNotFound", err)
+		}
+	})
+
+	t.Run("DeleteThenGet", func(t *testing.T) {
+		sid := session.SID("storetest-delete")
+		if err := store.Save(ctx, sid, testState{Name: "gopher"}); err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+		if err := store.Delete(ctx, sid); err != nil {
+			t.Fatalf("Delete() returned error: %v", err)
+		}
+
+		var got testState
+		err := store.Get(ctx, sid, &got)
+		if !errors.Is(err, session.ErrStateNotFound) {
+			t.Fatalf("Get() after Delete() returned %v, want ErrStateNotFound", err)
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		sid := session.SID("storetest-expiry")
+		if err := store.Save(ctx, sid, testState{Name: "gopher"}); err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+
+		time.Sleep(expiry + expiry/2)
+
+		var got testState
+		err := store.Get(ctx, sid, &got)
+		if !errors.Is(err, session.ErrStateNotFound) {
+			t.Fatalf("Get() after expiry returned %v, want ErrStateNotFound", err)
+		}
+	})
+}
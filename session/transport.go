@@ -0,0 +1,43 @@
+package session
+
+import "net/http"
+
+// Transport abstracts how a signed SID travels between server and client,
+// so that the same session middleware can be written once and backed by
+// either the Authorization header (HeaderTransport) or a cookie
+// (CookieTransport).
+type Transport interface {
+	// Extract reads and validates the SID carried by the request.
+	Extract(r *http.Request) (SID, error)
+	// Write attaches `sid` to the response so a later request can be
+	// extracted back to the same SID.
+	Write(w http.ResponseWriter, sid SID)
+	// Clear removes the SID so a later request is no longer associated
+	// with a session.
+	Clear(w http.ResponseWriter)
+}
+
+// HeaderTransport carries the signed SID in the Authorization header (or
+// the "auth" query parameter), as a "Bearer <sid>" value. This is the
+// transport BeginSession/GetSessionID/EndSession use.
+type HeaderTransport struct {
+	// SigningKeys is tried in order when validating an extracted SID;
+	// see ValidateID.
+	SigningKeys []string
+}
+
+// Extract reads the SID from the Authorization header or "auth" query
+// parameter.
+func (t HeaderTransport) Extract(r *http.Request) (SID, error) {
+	return GetSessionID(r, t.SigningKeys)
+}
+
+// Write sets the Authorization header to "Bearer <sid>".
+func (t HeaderTransport) Write(w http.ResponseWriter, sid SID) {
+	w.Header().Add(headerAuthorization, schemeBearer+string(sid))
+}
+
+// Clear is a no-op: a bearer token already delivered to a client cannot
+// be revoked by a response header, only by deleting its state in the
+// Store (see EndSession).
+func (t HeaderTransport) Clear(w http.ResponseWriter) {}